@@ -0,0 +1,99 @@
+// Package cache implements mapsscrap's on-disk cache: gzipped JSON blobs
+// keyed by an opaque string, stored one file per entry under a directory.
+// It's used both to replay previously scraped result pages and to avoid
+// re-geocoding addresses on repeat runs.
+package cache
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Store is a directory of gzipped JSON files, one per cache entry.
+//
+// A nil *Store is a valid, always-empty cache: every Get misses and every
+// Set is a no-op. This lets callers pass a nil Store when caching is
+// disabled (e.g. via --no-cache) without special-casing every call site.
+type Store struct {
+	dir string
+	ttl time.Duration
+}
+
+// NewStore creates dir if needed and returns a Store rooted there. A ttl
+// of zero means entries never expire.
+func NewStore(dir string, ttl time.Duration) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory %s: %w", dir, err)
+	}
+	return &Store{dir: dir, ttl: ttl}, nil
+}
+
+// Get looks up key and, on a hit, decodes the cached value into out.
+func (s *Store) Get(key string, out any) (bool, error) {
+	if s == nil {
+		return false, nil
+	}
+
+	path := s.entryPath(key)
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to stat cache entry: %w", err)
+	}
+	if s.ttl > 0 && time.Since(info.ModTime()) > s.ttl {
+		return false, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to open cache entry: %w", err)
+	}
+	defer file.Close()
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return false, fmt.Errorf("failed to decompress cache entry: %w", err)
+	}
+	defer reader.Close()
+
+	if err := json.NewDecoder(reader).Decode(out); err != nil {
+		return false, fmt.Errorf("failed to decode cache entry: %w", err)
+	}
+	return true, nil
+}
+
+// Set stores value under key, overwriting any existing entry.
+func (s *Store) Set(key string, value any) error {
+	if s == nil {
+		return nil
+	}
+
+	file, err := os.Create(s.entryPath(key))
+	if err != nil {
+		return fmt.Errorf("failed to create cache entry: %w", err)
+	}
+	defer file.Close()
+
+	writer := gzip.NewWriter(file)
+	defer writer.Close()
+
+	if err := json.NewEncoder(writer).Encode(value); err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+	return nil
+}
+
+// entryPath hashes key so arbitrary query strings and addresses are safe
+// to use as filenames.
+func (s *Store) entryPath(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+".json.gz")
+}
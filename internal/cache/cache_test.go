@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+type point struct {
+	Lat float64
+	Lon float64
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	store, err := NewStore(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	want := point{Lat: 1.5, Lon: -2.5}
+	if err := store.Set("123 Main St", want); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	var got point
+	ok, err := store.Get("123 Main St", &got)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected a cache hit")
+	}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestStoreMiss(t *testing.T) {
+	store, err := NewStore(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	var got point
+	ok, err := store.Get("nowhere", &got)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if ok {
+		t.Fatal("expected a cache miss")
+	}
+}
+
+func TestStoreExpires(t *testing.T) {
+	store, err := NewStore(t.TempDir(), time.Nanosecond)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	if err := store.Set("coffee:1,2,3", []string{"a"}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+
+	var got []string
+	ok, err := store.Get("coffee:1,2,3", &got)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if ok {
+		t.Fatal("expected the entry to have expired")
+	}
+}
+
+func TestNilStoreIsAlwaysEmpty(t *testing.T) {
+	var store *Store
+	if err := store.Set("k", "v"); err != nil {
+		t.Fatalf("Set on nil store: %v", err)
+	}
+
+	var got string
+	ok, err := store.Get("k", &got)
+	if err != nil {
+		t.Fatalf("Get on nil store: %v", err)
+	}
+	if ok {
+		t.Fatal("expected a nil store to always miss")
+	}
+}
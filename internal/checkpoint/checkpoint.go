@@ -0,0 +1,103 @@
+// Package checkpoint persists mid-run progress so a large grid search can
+// be interrupted (a crash, a tripped circuit breaker, Ctrl-C) and resumed
+// later without re-scraping the points it already finished.
+package checkpoint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/edlgg/mapsscrap/internal/sources"
+)
+
+// Checkpoint is the on-disk record of a run's progress: which grid indices
+// have been searched and the deduped places collected so far.
+type Checkpoint struct {
+	RunID            string          `json:"run_id"`
+	CompletedIndices []int           `json:"completed_indices"`
+	Places           []sources.Place `json:"places"`
+}
+
+// Store persists checkpoints as one JSON file per run under dir. A nil
+// *Store is valid and treats every Load as a miss and every Save/Delete as
+// a no-op, so callers don't need to special-case "checkpointing disabled".
+type Store struct {
+	dir string
+}
+
+// NewStore returns a Store backed by dir, creating it if necessary.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create checkpoint directory: %w", err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+// RunID derives a stable identifier for a run from the parameters that
+// define its grid, so resuming only kicks in when they match exactly.
+func RunID(query string, center sources.Coordinates, radiusKm, gridStepKm float64) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s|%.6f|%.6f|%.3f|%.3f", query, center.Lat, center.Lon, radiusKm, gridStepKm)
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+func (s *Store) path(runID string) string {
+	return filepath.Join(s.dir, runID+".json")
+}
+
+// Load reads the checkpoint for runID. found is false if none exists yet.
+func (s *Store) Load(runID string) (cp *Checkpoint, found bool, err error) {
+	if s == nil {
+		return nil, false, nil
+	}
+
+	data, err := os.ReadFile(s.path(runID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	var loaded Checkpoint
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return nil, false, fmt.Errorf("failed to parse checkpoint: %w", err)
+	}
+	return &loaded, true, nil
+}
+
+// Save writes cp to disk, overwriting any previous checkpoint for the same
+// RunID.
+func (s *Store) Save(cp *Checkpoint) error {
+	if s == nil {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+	if err := os.WriteFile(s.path(cp.RunID), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the checkpoint for runID, if any. It is called once a run
+// finishes successfully, or when --new-run discards a stale one.
+func (s *Store) Delete(runID string) error {
+	if s == nil {
+		return nil
+	}
+
+	err := os.Remove(s.path(runID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,111 @@
+package checkpoint
+
+import (
+	"testing"
+
+	"github.com/edlgg/mapsscrap/internal/sources"
+)
+
+func TestStoreRoundTrip(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	runID := RunID("coffee", sources.Coordinates{Lat: 1, Lon: 2}, 5, 2.5)
+	want := &Checkpoint{
+		RunID:            runID,
+		CompletedIndices: []int{0, 1, 2},
+		Places:           []sources.Place{{Name: "Joe's Coffee", Address: "123 Main St"}},
+	}
+	if err := store.Save(want); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, found, err := store.Load(runID)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !found {
+		t.Fatal("expected a checkpoint to be found")
+	}
+	if len(got.CompletedIndices) != 3 || len(got.Places) != 1 {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestStoreLoadMiss(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	_, found, err := store.Load(RunID("nowhere", sources.Coordinates{}, 1, 1))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if found {
+		t.Fatal("expected no checkpoint to be found")
+	}
+}
+
+func TestStoreDelete(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	runID := RunID("coffee", sources.Coordinates{Lat: 1, Lon: 2}, 5, 2.5)
+	if err := store.Save(&Checkpoint{RunID: runID}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := store.Delete(runID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	_, found, err := store.Load(runID)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if found {
+		t.Fatal("expected the checkpoint to be gone after Delete")
+	}
+
+	if err := store.Delete(runID); err != nil {
+		t.Fatalf("Delete on an already-deleted checkpoint should be a no-op: %v", err)
+	}
+}
+
+func TestRunIDIsStableAndSensitiveToParams(t *testing.T) {
+	center := sources.Coordinates{Lat: 40.7128, Lon: -74.0060}
+
+	a := RunID("coffee", center, 5, 2.5)
+	b := RunID("coffee", center, 5, 2.5)
+	if a != b {
+		t.Errorf("expected RunID to be deterministic, got %q and %q", a, b)
+	}
+
+	c := RunID("tea", center, 5, 2.5)
+	if a == c {
+		t.Error("expected a different query to produce a different RunID")
+	}
+}
+
+func TestNilStoreIsAlwaysEmpty(t *testing.T) {
+	var store *Store
+
+	if err := store.Save(&Checkpoint{RunID: "x"}); err != nil {
+		t.Fatalf("Save on nil store: %v", err)
+	}
+	if err := store.Delete("x"); err != nil {
+		t.Fatalf("Delete on nil store: %v", err)
+	}
+
+	_, found, err := store.Load("x")
+	if err != nil {
+		t.Fatalf("Load on nil store: %v", err)
+	}
+	if found {
+		t.Fatal("expected a nil store to always miss")
+	}
+}
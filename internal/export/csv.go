@@ -0,0 +1,51 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+
+	"github.com/edlgg/mapsscrap/internal/sources"
+)
+
+func init() {
+	Register(csvExporter{})
+}
+
+type csvExporter struct{}
+
+func (csvExporter) Name() string { return "csv" }
+
+// Export writes places to basePath+".csv".
+func (csvExporter) Export(places []sources.Place, basePath string) error {
+	file, err := os.Create(basePath + ".csv")
+	if err != nil {
+		return fmt.Errorf("failed to create CSV file: %w", err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	header := []string{"Name", "Address", "Stars", "Reviews", "Phone", "Hours", "Website"}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write header to CSV: %w", err)
+	}
+
+	for _, place := range places {
+		record := []string{
+			place.Name,
+			place.Address,
+			fmt.Sprintf("%.1f", place.Stars),
+			fmt.Sprintf("%d", place.Reviews),
+			place.Phone,
+			place.Hours,
+			place.Website,
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write record to CSV: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+// Package export implements mapsscrap's output sinks. Scraped places can
+// be written as CSV, NDJSON, GeoJSON, or into a growing SQLite prospect
+// database, selected by name via --output-format.
+package export
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/edlgg/mapsscrap/internal/sources"
+)
+
+// Exporter writes a batch of places to basePath, appending whatever file
+// extension suits its format.
+type Exporter interface {
+	// Name identifies the format, matching the key it was registered under.
+	Name() string
+	// Export writes places to basePath (a path with no extension).
+	Export(places []sources.Place, basePath string) error
+}
+
+var registry = map[string]Exporter{}
+
+// Register makes an Exporter available under its own Name(). Implementations
+// call this from an init function so importing the export package is
+// enough to make them available.
+func Register(exporter Exporter) {
+	registry[exporter.Name()] = exporter
+}
+
+// Get looks up a registered Exporter by name.
+func Get(name string) (Exporter, error) {
+	exporter, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown output format %q (available: %v)", name, Names())
+	}
+	return exporter, nil
+}
+
+// Names returns the registered output format names, sorted.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
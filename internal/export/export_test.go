@@ -0,0 +1,144 @@
+package export
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/edlgg/mapsscrap/internal/sources"
+)
+
+var samplePlace = sources.Place{
+	Name:        "Joe's Coffee",
+	Address:     "123 Main St",
+	Stars:       4.5,
+	Reviews:     123,
+	Coordinates: sources.Coordinates{Lat: 40.7128, Lon: -74.0060},
+	Website:     "https://joescoffee.example.com",
+}
+
+func TestNamesIncludesBuiltinFormats(t *testing.T) {
+	names := Names()
+
+	want := map[string]bool{"csv": false, "ndjson": false, "geojson": false, "sqlite": false}
+	for _, name := range names {
+		if _, ok := want[name]; ok {
+			want[name] = true
+		}
+	}
+	for name, found := range want {
+		if !found {
+			t.Errorf("expected %q to be registered, got %v", name, names)
+		}
+	}
+}
+
+func TestGetUnknownFormat(t *testing.T) {
+	if _, err := Get("xml"); err == nil {
+		t.Fatal("expected an error for an unregistered output format")
+	}
+}
+
+func TestCSVExport(t *testing.T) {
+	basePath := filepath.Join(t.TempDir(), "prospects")
+
+	exporter, err := Get("csv")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if err := exporter.Export([]sources.Place{samplePlace}, basePath); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	content, err := os.ReadFile(basePath + ".csv")
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !strings.Contains(string(content), "Joe's Coffee") {
+		t.Errorf("CSV output missing place name: %s", content)
+	}
+}
+
+func TestNDJSONExport(t *testing.T) {
+	basePath := filepath.Join(t.TempDir(), "prospects")
+
+	exporter, err := Get("ndjson")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if err := exporter.Export([]sources.Place{samplePlace}, basePath); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	content, err := os.ReadFile(basePath + ".ndjson")
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !strings.Contains(string(content), `"name":"Joe's Coffee"`) {
+		t.Errorf("NDJSON output missing place name: %s", content)
+	}
+}
+
+func TestGeoJSONExport(t *testing.T) {
+	basePath := filepath.Join(t.TempDir(), "prospects")
+
+	exporter, err := Get("geojson")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if err := exporter.Export([]sources.Place{samplePlace}, basePath); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	content, err := os.ReadFile(basePath + ".geojson")
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !strings.Contains(string(content), `"FeatureCollection"`) {
+		t.Errorf("GeoJSON output missing FeatureCollection: %s", content)
+	}
+	if !strings.Contains(string(content), "-74.006") {
+		t.Errorf("GeoJSON output missing place coordinates: %s", content)
+	}
+}
+
+func TestSQLiteExportDedupesAndAccumulates(t *testing.T) {
+	basePath := filepath.Join(t.TempDir(), "prospects")
+	otherPlace := sources.Place{
+		Name:        "Jane's Bakery",
+		Address:     "456 Elm St",
+		Coordinates: sources.Coordinates{Lat: 40.73, Lon: -73.99},
+	}
+
+	exporter, err := Get("sqlite")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if err := exporter.Export([]sources.Place{samplePlace}, basePath); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	// Re-exporting the same place (same name+address) alongside a new one
+	// should dedupe the former and accumulate the latter into the same
+	// database, not produce a duplicate row or a fresh one-shot file.
+	if err := exporter.Export([]sources.Place{samplePlace, otherPlace}, basePath); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	db, err := sql.Open("sqlite3", basePath+".db")
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	defer db.Close()
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM places").Scan(&count); err != nil {
+		t.Fatalf("QueryRow: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("got %d rows after exporting the same place twice plus one new place, want 2", count)
+	}
+}
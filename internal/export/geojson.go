@@ -0,0 +1,78 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/edlgg/mapsscrap/internal/sources"
+)
+
+func init() {
+	Register(geoJSONExporter{})
+}
+
+type geoJSONExporter struct{}
+
+func (geoJSONExporter) Name() string { return "geojson" }
+
+// geoJSONFeatureCollection and geoJSONFeature mirror just enough of the
+// GeoJSON spec (RFC 7946) for point features to drop straight into
+// QGIS/Kepler.gl/Leaflet.
+type geoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Type       string          `json:"type"`
+	Geometry   geoJSONGeometry `json:"geometry"`
+	Properties map[string]any  `json:"properties"`
+}
+
+type geoJSONGeometry struct {
+	Type        string    `json:"type"`
+	Coordinates []float64 `json:"coordinates"`
+}
+
+// Export writes places to basePath+".geojson" as a FeatureCollection of
+// Point features.
+func (geoJSONExporter) Export(places []sources.Place, basePath string) error {
+	collection := geoJSONFeatureCollection{
+		Type:     "FeatureCollection",
+		Features: make([]geoJSONFeature, 0, len(places)),
+	}
+
+	for _, place := range places {
+		collection.Features = append(collection.Features, geoJSONFeature{
+			Type: "Feature",
+			Geometry: geoJSONGeometry{
+				Type:        "Point",
+				Coordinates: []float64{place.Coordinates.Lon, place.Coordinates.Lat},
+			},
+			Properties: map[string]any{
+				"name":    place.Name,
+				"address": place.Address,
+				"stars":   place.Stars,
+				"reviews": place.Reviews,
+				"hours":   place.Hours,
+				"phone":   place.Phone,
+				"website": place.Website,
+			},
+		})
+	}
+
+	file, err := os.Create(basePath + ".geojson")
+	if err != nil {
+		return fmt.Errorf("failed to create GeoJSON file: %w", err)
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(collection); err != nil {
+		return fmt.Errorf("failed to write GeoJSON: %w", err)
+	}
+
+	return nil
+}
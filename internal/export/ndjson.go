@@ -0,0 +1,35 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/edlgg/mapsscrap/internal/sources"
+)
+
+func init() {
+	Register(ndjsonExporter{})
+}
+
+type ndjsonExporter struct{}
+
+func (ndjsonExporter) Name() string { return "ndjson" }
+
+// Export writes places to basePath+".ndjson", one JSON object per line.
+func (ndjsonExporter) Export(places []sources.Place, basePath string) error {
+	file, err := os.Create(basePath + ".ndjson")
+	if err != nil {
+		return fmt.Errorf("failed to create NDJSON file: %w", err)
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	for _, place := range places {
+		if err := encoder.Encode(place); err != nil {
+			return fmt.Errorf("failed to write NDJSON record: %w", err)
+		}
+	}
+
+	return nil
+}
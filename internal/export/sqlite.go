@@ -0,0 +1,81 @@
+package export
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/edlgg/mapsscrap/internal/sources"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func init() {
+	Register(sqliteExporter{})
+}
+
+type sqliteExporter struct{}
+
+func (sqliteExporter) Name() string { return "sqlite" }
+
+// Export inserts places into basePath+".db", a growing prospect database:
+// repeated runs accumulate into the same file instead of producing a new
+// one-shot export. Rows are deduped by (name, address); a place already in
+// the database is left unchanged rather than updated.
+func (sqliteExporter) Export(places []sources.Place, basePath string) error {
+	db, err := sql.Open("sqlite3", basePath+".db")
+	if err != nil {
+		return fmt.Errorf("failed to open SQLite database: %w", err)
+	}
+	defer db.Close()
+
+	if err := createPlacesTable(db); err != nil {
+		return err
+	}
+
+	stmt, err := db.Prepare(`
+		INSERT OR IGNORE INTO places (name, address, stars, reviews, lat, lon, hours, phone, website)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare insert statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, place := range places {
+		_, err := stmt.Exec(
+			place.Name,
+			place.Address,
+			place.Stars,
+			place.Reviews,
+			place.Coordinates.Lat,
+			place.Coordinates.Lon,
+			place.Hours,
+			place.Phone,
+			place.Website,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert place %q: %w", place.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func createPlacesTable(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS places (
+			name    TEXT NOT NULL,
+			address TEXT NOT NULL,
+			stars   REAL,
+			reviews INTEGER,
+			lat     REAL,
+			lon     REAL,
+			hours   TEXT,
+			phone   TEXT,
+			website TEXT,
+			UNIQUE(name, address)
+		);
+		CREATE INDEX IF NOT EXISTS idx_places_lat_lon ON places(lat, lon);`)
+	if err != nil {
+		return fmt.Errorf("failed to create places table: %w", err)
+	}
+	return nil
+}
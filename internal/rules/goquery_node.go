@@ -0,0 +1,39 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// goqueryNode adapts a *goquery.Selection to Node. This backs rule
+// evaluation against static HTML: unit tests feeding saved page snippets,
+// live scraped pages, and cached pages replayed without a browser.
+type goqueryNode struct {
+	sel *goquery.Selection
+}
+
+// NewGoqueryNode wraps a goquery selection so it can be walked by the rule engine.
+func NewGoqueryNode(sel *goquery.Selection) Node {
+	return goqueryNode{sel: sel}
+}
+
+func (n goqueryNode) Find(selector string) (Node, bool) {
+	sel := n.sel.Find(selector)
+	if sel.Length() == 0 {
+		return nil, false
+	}
+	return goqueryNode{sel: sel.First()}, true
+}
+
+func (n goqueryNode) Text() (string, error) {
+	return n.sel.Text(), nil
+}
+
+func (n goqueryNode) Attribute(name string) (string, error) {
+	val, ok := n.sel.Attr(name)
+	if !ok {
+		return "", fmt.Errorf("attribute %q not present", name)
+	}
+	return val, nil
+}
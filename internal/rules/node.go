@@ -0,0 +1,13 @@
+package rules
+
+// Node is a minimal abstraction over a single DOM element. It lets the rule
+// engine run against static HTML parsed from scraped or cached pages (see
+// NewGoqueryNode) without caring where that HTML came from.
+type Node interface {
+	// Find returns the first descendant matching selector, if any.
+	Find(selector string) (Node, bool)
+	// Text returns the element's rendered text content.
+	Text() (string, error)
+	// Attribute returns the named attribute's value.
+	Attribute(name string) (string, error)
+}
@@ -0,0 +1,171 @@
+// Package rules implements mapsscrap's pluggable extraction engine. Instead
+// of hard-coding CSS selectors in the scraper, field rules are declared in
+// YAML or JSON files and walked generically against each result element.
+// This lets a broken selector be fixed by dropping in a new rules file
+// rather than recompiling the tool.
+package rules
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FieldType is the Go type a captured field value should be converted to.
+type FieldType string
+
+const (
+	TypeString FieldType = "string"
+	TypeFloat  FieldType = "float"
+	TypeInt    FieldType = "int"
+)
+
+// FieldRule describes how to extract a single field from a place element.
+type FieldRule struct {
+	Name      string    `yaml:"name" json:"name"`
+	Selector  string    `yaml:"selector" json:"selector"`
+	Attribute string    `yaml:"attribute,omitempty" json:"attribute,omitempty"`
+	Pattern   string    `yaml:"pattern,omitempty" json:"pattern,omitempty"`
+	Type      FieldType `yaml:"type,omitempty" json:"type,omitempty"`
+}
+
+// RuleSet is a named collection of field rules scoped to a result container
+// selector (e.g. one business card in the Google Maps results list).
+type RuleSet struct {
+	Name   string      `yaml:"name" json:"name"`
+	Target string      `yaml:"target" json:"target"`
+	Fields []FieldRule `yaml:"fields" json:"fields"`
+}
+
+//go:embed default.yaml
+var defaultRuleSetYAML []byte
+
+// Default returns the built-in rule set matching mapsscrap's original
+// hard-coded Google Maps selectors, so runs with no --rules-dir behave
+// exactly as before.
+func Default() (RuleSet, error) {
+	return parseRuleSet("default.yaml", defaultRuleSetYAML)
+}
+
+// LoadDir loads every *.yaml, *.yml and *.json rule file found directly
+// inside dir.
+func LoadDir(dir string) ([]RuleSet, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules directory: %w", err)
+	}
+
+	var ruleSets []RuleSet
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rule file %s: %w", path, err)
+		}
+		ruleSet, err := parseRuleSet(entry.Name(), data)
+		if err != nil {
+			return nil, err
+		}
+		ruleSets = append(ruleSets, ruleSet)
+	}
+
+	if len(ruleSets) == 0 {
+		return nil, fmt.Errorf("no rule files found in %s", dir)
+	}
+	return ruleSets, nil
+}
+
+func parseRuleSet(name string, data []byte) (RuleSet, error) {
+	var rs RuleSet
+	// JSON is a subset of YAML, so yaml.Unmarshal handles both .json and
+	// .yaml/.yml rule files.
+	if err := yaml.Unmarshal(data, &rs); err != nil {
+		return RuleSet{}, fmt.Errorf("failed to parse rule file %s: %w", name, err)
+	}
+	return rs, nil
+}
+
+// Extract walks a RuleSet's field rules against a container node and
+// returns the captured values keyed by field name. Fields whose selector
+// doesn't match, or whose pattern fails to capture, are simply omitted.
+func Extract(container Node, rs RuleSet) map[string]any {
+	fields := make(map[string]any)
+
+	for _, rule := range rs.Fields {
+		node := container
+		if rule.Selector != "" {
+			var ok bool
+			node, ok = container.Find(rule.Selector)
+			if !ok {
+				continue
+			}
+		}
+
+		raw, err := readValue(node, rule)
+		if err != nil {
+			continue
+		}
+
+		value, err := convert(raw, rule.Type)
+		if err != nil {
+			continue
+		}
+
+		fields[rule.Name] = value
+	}
+
+	return fields
+}
+
+func readValue(node Node, rule FieldRule) (string, error) {
+	var raw string
+	var err error
+	if rule.Attribute != "" {
+		raw, err = node.Attribute(rule.Attribute)
+	} else {
+		raw, err = node.Text()
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if rule.Pattern == "" {
+		return strings.TrimSpace(raw), nil
+	}
+
+	re, err := regexp.Compile(rule.Pattern)
+	if err != nil {
+		return "", fmt.Errorf("invalid pattern for field %s: %w", rule.Name, err)
+	}
+	matches := re.FindStringSubmatch(raw)
+	if len(matches) < 2 {
+		return "", fmt.Errorf("pattern did not match for field %s", rule.Name)
+	}
+	return strings.TrimSpace(matches[1]), nil
+}
+
+func convert(raw string, fieldType FieldType) (any, error) {
+	switch fieldType {
+	case TypeFloat:
+		return strconv.ParseFloat(raw, 64)
+	case TypeInt:
+		return strconv.Atoi(raw)
+	default:
+		return raw, nil
+	}
+}
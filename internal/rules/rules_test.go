@@ -0,0 +1,64 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const sampleResultHTML = `
+<div class="Nv2PK">
+  <div class="qBF1Pd fontHeadlineSmall">Joe's Coffee</div>
+  <span class="MW4etd">4.5</span>
+  <span class="UY7F9">(123)</span>
+  <div class="rowA">
+    <div class="W4Efsd">Cafe · 123 Main St</div>
+    <div class="W4Efsd">Open · 9AM-5PM</div>
+  </div>
+  <a class="lcr4fd" href="https://joescoffee.example.com">Website</a>
+</div>
+`
+
+func TestExtractDefaultRuleSet(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(sampleResultHTML))
+	if err != nil {
+		t.Fatalf("failed to parse sample HTML: %v", err)
+	}
+
+	ruleSet, err := Default()
+	if err != nil {
+		t.Fatalf("failed to load default rule set: %v", err)
+	}
+
+	container := NewGoqueryNode(doc.Find(ruleSet.Target).First())
+	fields := Extract(container, ruleSet)
+
+	tests := []struct {
+		field string
+		want  any
+	}{
+		{"name", "Joe's Coffee"},
+		{"stars", 4.5},
+		{"reviews", 123},
+		{"address", "123 Main St"},
+		{"hours", "Open"},
+		{"website", "https://joescoffee.example.com"},
+	}
+
+	for _, tt := range tests {
+		if got := fields[tt.field]; got != tt.want {
+			t.Errorf("fields[%q] = %v, want %v", tt.field, got, tt.want)
+		}
+	}
+
+	if _, ok := fields["phone"]; ok {
+		t.Errorf("fields[%q] = %v, want absent", "phone", fields["phone"])
+	}
+}
+
+func TestLoadDirRejectsEmptyDir(t *testing.T) {
+	if _, err := LoadDir(t.TempDir()); err == nil {
+		t.Fatal("expected an error loading an empty rules directory")
+	}
+}
@@ -0,0 +1,342 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/edlgg/mapsscrap/internal/cache"
+	"github.com/edlgg/mapsscrap/internal/rules"
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/rod/lib/proto"
+	"golang.org/x/time/rate"
+)
+
+// nominatimLimiter throttles geocodeViaNominatim to Nominatim's documented
+// anonymous usage cap of one request per second, shared across every
+// GoogleSource and goroutine in the process - searchWorker runs up to
+// maxWorkers Search calls concurrently, and each can geocode several
+// places, so without a shared limiter a single batch can burst well past
+// that cap.
+var nominatimLimiter = rate.NewLimiter(rate.Limit(1), 1)
+
+func init() {
+	Register("google", newGoogleSource)
+}
+
+// GoogleSource scrapes Google Maps with a headless browser, extracting
+// each result via the active rule set and enriching it with a geocoded
+// address, both backed by on-disk caches.
+type GoogleSource struct {
+	ruleSet   rules.RuleSet
+	pageCache *cache.Store
+	geoCache  *cache.Store
+}
+
+func newGoogleSource(opts Options) (MapSource, error) {
+	ruleSet, err := loadRuleSet(opts.RulesDir, opts.RuleName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load scraper rules: %w", err)
+	}
+
+	pageCache, geoCache, err := openCaches(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cache: %w", err)
+	}
+
+	return &GoogleSource{ruleSet: ruleSet, pageCache: pageCache, geoCache: geoCache}, nil
+}
+
+// loadRuleSet returns the built-in Google Maps rules, or the rule set
+// loaded from rulesDir when one is given via --rules-dir. When rulesDir
+// contains more than one rule file, ruleName (--rule-name) picks which one
+// is active by its Name field; leaving ruleName empty is only valid when
+// rulesDir contains exactly one file, since there would otherwise be no
+// documented ordering to fall back on.
+func loadRuleSet(rulesDir, ruleName string) (rules.RuleSet, error) {
+	if rulesDir == "" {
+		return rules.Default()
+	}
+
+	ruleSets, err := rules.LoadDir(rulesDir)
+	if err != nil {
+		return rules.RuleSet{}, err
+	}
+
+	if len(ruleSets) == 1 {
+		if ruleName != "" && ruleSets[0].Name != ruleName {
+			return rules.RuleSet{}, fmt.Errorf("rule set %q not found in %s (found: %q)", ruleName, rulesDir, ruleSets[0].Name)
+		}
+		return ruleSets[0], nil
+	}
+
+	names := make([]string, len(ruleSets))
+	for i, rs := range ruleSets {
+		names[i] = rs.Name
+	}
+
+	if ruleName == "" {
+		return rules.RuleSet{}, fmt.Errorf("%s contains %d rule files (%v); pick one with --rule-name", rulesDir, len(ruleSets), names)
+	}
+	for _, rs := range ruleSets {
+		if rs.Name == ruleName {
+			return rs, nil
+		}
+	}
+	return rules.RuleSet{}, fmt.Errorf("rule set %q not found in %s (found: %v)", ruleName, rulesDir, names)
+}
+
+// openCaches sets up the page and geocode caches under opts.CacheDir, or
+// returns two nil stores (which always miss and never write) when caching
+// is disabled.
+func openCaches(opts Options) (pageCache, geoCache *cache.Store, err error) {
+	if opts.NoCache {
+		return nil, nil, nil
+	}
+
+	pageCache, err = cache.NewStore(filepath.Join(opts.CacheDir, "pages"), opts.CacheTTL)
+	if err != nil {
+		return nil, nil, err
+	}
+	geoCache, err = cache.NewStore(filepath.Join(opts.CacheDir, "geocode"), opts.CacheTTL)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pageCache, geoCache, nil
+}
+
+func (s *GoogleSource) Name() string { return "google" }
+
+// Search performs the actual scraping of Google Maps, mapping HTML
+// elements to places via the active rule set. The raw results page markup
+// is cached independently of the active rule set, so a cache hit still
+// runs the current rules against it: fixing a broken selector with
+// --rules-dir takes effect immediately, even against a warm cache, without
+// re-hitting Google.
+func (s *GoogleSource) Search(ctx context.Context, params SearchParams) ([]Place, error) {
+	pageKey := pageCacheKey(params)
+	var html string
+	if ok, err := s.pageCache.Get(pageKey, &html); err == nil && ok {
+		return s.extractPlacesFromHTML(ctx, html, params)
+	}
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	// Launch browser
+	launch := launcher.New().
+		Headless(true).
+		Devtools(false)
+
+	launchURL, err := launch.Launch()
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch browser: %w", err)
+	}
+
+	// Binding ctx here means every chained call below (Navigate,
+	// MustWaitStable, MustElement...) observes the caller's context: once it
+	// is cancelled (searchWorker does so on its 45s timeout), the in-flight
+	// CDP calls are aborted instead of running to completion.
+	browser := rod.New().ControlURL(launchURL).Context(ctx).MustConnect()
+	defer browser.Close()
+
+	page := browser.MustPage()
+	defer page.Close()
+
+	// Navigate to Google Maps
+	mapURL := fmt.Sprintf("https://www.google.com/maps/search/%s/@%f,%f,15z",
+		params.Query,
+		params.Latitude,
+		params.Longitude,
+	)
+
+	if err := page.Navigate(mapURL); err != nil {
+		return nil, fmt.Errorf("failed to navigate: %w", err)
+	}
+
+	page.MustWaitStable()
+
+	listDivClass := "m6QErb.DxyBCb.kA9KIf.dS8AEf"
+
+	container := page.MustElement("div." + listDivClass)
+	container.MustWaitVisible()
+
+	// move mouse pointer to list which is first third of screen and scroll
+	for i := 0; i < 10; i++ { // 10
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		page.Mouse.MoveTo(proto.Point{X: 250, Y: 300})
+		page.Mouse.Scroll(0.0, 6000.0, 30)
+		// page.Mouse.Scroll(0.0, 1000.0, 5)
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	html, err = container.HTML()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read results page markup: %w", err)
+	}
+
+	if err := s.pageCache.Set(pageKey, html); err != nil {
+		fmt.Printf("Error caching result page: %v\n", err)
+	}
+
+	return s.extractPlacesFromHTML(ctx, html, params)
+}
+
+// pageCacheKey builds the page cache key for a search at a given grid
+// point: the cached markup is specific to the query, center, and radius
+// searched, not to the rule set used to extract it - extractPlacesFromHTML
+// re-runs the active rules against it on every call, cache hit or miss.
+func pageCacheKey(params SearchParams) string {
+	return fmt.Sprintf("%s:%.6f:%.6f:%.2f", params.Query, params.Latitude, params.Longitude, params.RadiusKm)
+}
+
+// extractPlacesFromHTML parses the results page markup (freshly scraped or
+// replayed from the page cache) and runs the active rule set against every
+// matching element, so a rule change always reflects the current rules
+// even when the underlying page came from cache.
+func (s *GoogleSource) extractPlacesFromHTML(ctx context.Context, html string, params SearchParams) ([]Place, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse results page markup: %w", err)
+	}
+
+	places := []Place{}
+	doc.Find(s.ruleSet.Target).Each(func(_ int, sel *goquery.Selection) {
+		place := s.extractPlaceDetails(ctx, rules.NewGoqueryNode(sel), params)
+		if place.Name != "" {
+			places = append(places, place)
+		}
+	})
+
+	return places, nil
+}
+
+// extractPlaceDetails extracts details of a place from the given node. It
+// runs the active rule set's field rules against the node, maps the
+// captured values onto a Place, then enriches the place with its real
+// geocoded coordinates in place of the grid point it was found at.
+func (s *GoogleSource) extractPlaceDetails(ctx context.Context, node rules.Node, params SearchParams) Place {
+	place := Place{
+		Coordinates: Coordinates{
+			Lat: params.Latitude,
+			Lon: params.Longitude,
+		},
+	}
+
+	fields := rules.Extract(node, s.ruleSet)
+	applyFields(&place, fields)
+
+	if place.Address != "" {
+		if coords, ok := s.geocodeAddress(ctx, place.Address); ok {
+			place.Coordinates = coords
+		}
+	}
+
+	return place
+}
+
+// applyFields maps the rule engine's captured values onto a Place's fields
+// by name. Unrecognized or missing fields are left untouched.
+func applyFields(place *Place, fields map[string]any) {
+	if v, ok := fields["name"].(string); ok {
+		place.Name = v
+	}
+	if v, ok := fields["address"].(string); ok {
+		place.Address = v
+	}
+	if v, ok := fields["stars"].(float64); ok {
+		place.Stars = v
+	}
+	if v, ok := fields["reviews"].(int); ok {
+		place.Reviews = v
+	}
+	if v, ok := fields["hours"].(string); ok {
+		place.Hours = v
+	}
+	if v, ok := fields["phone"].(string); ok {
+		place.Phone = v
+	}
+	if v, ok := fields["website"].(string); ok {
+		place.Website = v
+	}
+}
+
+// geocodeAddress resolves address to coordinates, checking the geocode
+// cache first and populating it on a miss.
+func (s *GoogleSource) geocodeAddress(ctx context.Context, address string) (Coordinates, bool) {
+	var coords Coordinates
+	if ok, err := s.geoCache.Get(address, &coords); err == nil && ok {
+		return coords, true
+	}
+
+	coords, err := geocodeViaNominatim(ctx, address)
+	if err != nil {
+		return Coordinates{}, false
+	}
+
+	if err := s.geoCache.Set(address, coords); err != nil {
+		fmt.Printf("Error caching geocode result: %v\n", err)
+	}
+	return coords, true
+}
+
+// geocodeViaNominatim resolves an address to coordinates using the public
+// OpenStreetMap Nominatim API. Nominatim's usage policy caps anonymous
+// traffic at one request per second; nominatimLimiter enforces that across
+// every concurrent caller instead of relying on callers to cache results.
+func geocodeViaNominatim(ctx context.Context, address string) (Coordinates, error) {
+	if err := nominatimLimiter.Wait(ctx); err != nil {
+		return Coordinates{}, fmt.Errorf("failed to wait for geocode rate limit: %w", err)
+	}
+
+	endpoint := "https://nominatim.openstreetmap.org/search?" + url.Values{
+		"q":      {address},
+		"format": {"json"},
+		"limit":  {"1"},
+	}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return Coordinates{}, fmt.Errorf("failed to build geocode request: %w", err)
+	}
+	req.Header.Set("User-Agent", "mapsscrap/1.0")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return Coordinates{}, fmt.Errorf("failed to geocode address: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var results []struct {
+		Lat string `json:"lat"`
+		Lon string `json:"lon"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return Coordinates{}, fmt.Errorf("failed to decode geocode response: %w", err)
+	}
+	if len(results) == 0 {
+		return Coordinates{}, fmt.Errorf("no geocode results for address %q", address)
+	}
+
+	lat, err := strconv.ParseFloat(results[0].Lat, 64)
+	if err != nil {
+		return Coordinates{}, fmt.Errorf("failed to parse geocoded latitude: %w", err)
+	}
+	lon, err := strconv.ParseFloat(results[0].Lon, 64)
+	if err != nil {
+		return Coordinates{}, fmt.Errorf("failed to parse geocoded longitude: %w", err)
+	}
+
+	return Coordinates{Lat: lat, Lon: lon}, nil
+}
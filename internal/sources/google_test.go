@@ -0,0 +1,134 @@
+package sources
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/edlgg/mapsscrap/internal/cache"
+	"github.com/edlgg/mapsscrap/internal/rules"
+)
+
+func writeRuleFile(t *testing.T, dir, fileName, ruleName string) {
+	t.Helper()
+	content := "name: " + ruleName + "\ntarget: div.x\nfields: []\n"
+	if err := os.WriteFile(filepath.Join(dir, fileName), []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write rule file: %v", err)
+	}
+}
+
+func TestLoadRuleSetDefaultsToBuiltin(t *testing.T) {
+	rs, err := loadRuleSet("", "")
+	if err != nil {
+		t.Fatalf("loadRuleSet: %v", err)
+	}
+	if rs.Name != "google-maps-default" {
+		t.Errorf("got rule set %q, want the built-in default", rs.Name)
+	}
+}
+
+func TestLoadRuleSetSingleFileNeedsNoRuleName(t *testing.T) {
+	dir := t.TempDir()
+	writeRuleFile(t, dir, "custom.yaml", "custom")
+
+	rs, err := loadRuleSet(dir, "")
+	if err != nil {
+		t.Fatalf("loadRuleSet: %v", err)
+	}
+	if rs.Name != "custom" {
+		t.Errorf("got rule set %q, want %q", rs.Name, "custom")
+	}
+}
+
+func TestLoadRuleSetMultipleFilesRequireRuleName(t *testing.T) {
+	dir := t.TempDir()
+	writeRuleFile(t, dir, "a.yaml", "a")
+	writeRuleFile(t, dir, "b.yaml", "b")
+
+	if _, err := loadRuleSet(dir, ""); err == nil {
+		t.Fatal("expected an error when --rules-dir has multiple files and no --rule-name is given")
+	}
+
+	rs, err := loadRuleSet(dir, "b")
+	if err != nil {
+		t.Fatalf("loadRuleSet: %v", err)
+	}
+	if rs.Name != "b" {
+		t.Errorf("got rule set %q, want %q", rs.Name, "b")
+	}
+
+	if _, err := loadRuleSet(dir, "missing"); err == nil {
+		t.Fatal("expected an error for an unknown --rule-name")
+	}
+}
+
+func TestNominatimLimiterCapsToOneRequestPerSecond(t *testing.T) {
+	if got := nominatimLimiter.Limit(); got != 1 {
+		t.Errorf("got rate limit %v, want 1 request/second", got)
+	}
+	if got := nominatimLimiter.Burst(); got != 1 {
+		t.Errorf("got burst %d, want 1", got)
+	}
+}
+
+func TestSearchCacheHitReflectsCurrentRuleSet(t *testing.T) {
+	pageCache, err := cache.NewStore(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("cache.NewStore: %v", err)
+	}
+
+	html := `<div class="card"><span class="new-name">New Selector Place</span></div>`
+	params := SearchParams{Query: "cafes", Latitude: 1, Longitude: 2, RadiusKm: 5}
+	if err := pageCache.Set(pageCacheKey(params), html); err != nil {
+		t.Fatalf("pageCache.Set: %v", err)
+	}
+
+	brokenRules := rules.RuleSet{
+		Target: "div.card",
+		Fields: []rules.FieldRule{{Name: "name", Selector: "span.wrong-selector"}},
+	}
+	source := &GoogleSource{ruleSet: brokenRules, pageCache: pageCache}
+
+	places, err := source.Search(context.Background(), params)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(places) != 0 {
+		t.Fatalf("got %d places with a non-matching selector, want 0", len(places))
+	}
+
+	// Same cached page, same cache key, but the broken selector has been
+	// fixed. The cache hit must re-run the new rules rather than silently
+	// replaying the old (empty) extraction.
+	source.ruleSet = rules.RuleSet{
+		Target: "div.card",
+		Fields: []rules.FieldRule{{Name: "name", Selector: "span.new-name"}},
+	}
+
+	places, err = source.Search(context.Background(), params)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(places) != 1 || places[0].Name != "New Selector Place" {
+		t.Fatalf("got %v, want a single place named %q picked up from the warm cache after fixing the rule set", places, "New Selector Place")
+	}
+}
+
+func TestGeocodeViaNominatimRespectsCancelledContext(t *testing.T) {
+	// Drain the limiter's single token so the next Wait would normally
+	// block, then confirm an already-cancelled context fails fast instead
+	// of making an outbound request.
+	if !nominatimLimiter.Allow() {
+		t.Fatal("expected to consume the limiter's initial token")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	time.Sleep(time.Millisecond)
+
+	if _, err := geocodeViaNominatim(ctx, "123 Main St"); err == nil {
+		t.Fatal("expected geocodeViaNominatim to fail once its context deadline is exceeded")
+	}
+}
@@ -0,0 +1,146 @@
+package sources
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+func init() {
+	Register("osm", newOSMSource)
+}
+
+const overpassEndpoint = "https://overpass-api.de/api/interpreter"
+
+// OSMSource searches OpenStreetMap via the Overpass API instead of
+// driving a headless browser: it's faster, avoids Google's rate limiting,
+// and needs no browser automation dependency.
+type OSMSource struct {
+	client *http.Client
+}
+
+func newOSMSource(opts Options) (MapSource, error) {
+	return &OSMSource{client: http.DefaultClient}, nil
+}
+
+func (s *OSMSource) Name() string { return "osm" }
+
+// Search runs an Overpass QL query for nodes and ways near the search
+// point whose name matches params.Query, and maps their tags onto Places.
+func (s *OSMSource) Search(ctx context.Context, params SearchParams) ([]Place, error) {
+	query := buildOverpassQuery(params)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, overpassEndpoint, bytes.NewBufferString("data="+query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build overpass request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query overpass: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read overpass response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("overpass returned %s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+
+	var result overpassResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode overpass response: %w", err)
+	}
+
+	places := make([]Place, 0, len(result.Elements))
+	for _, element := range result.Elements {
+		place := elementToPlace(element)
+		if place.Name != "" {
+			places = append(places, place)
+		}
+	}
+
+	return places, nil
+}
+
+// buildOverpassQuery builds an Overpass QL query for named nodes and ways
+// whose name matches params.Query within the search radius.
+func buildOverpassQuery(params SearchParams) string {
+	radiusMeters := params.RadiusKm * 1000
+	return fmt.Sprintf(`[out:json][timeout:25];
+(
+  node["name"~"%s",i](around:%.0f,%f,%f);
+  way["name"~"%s",i](around:%.0f,%f,%f);
+);
+out center tags;`,
+		escapeOverpassRegex(params.Query), radiusMeters, params.Latitude, params.Longitude,
+		escapeOverpassRegex(params.Query), radiusMeters, params.Latitude, params.Longitude,
+	)
+}
+
+func escapeOverpassRegex(query string) string {
+	return strings.NewReplacer(`"`, `\"`, `\`, `\\`).Replace(query)
+}
+
+type overpassResponse struct {
+	Elements []overpassElement `json:"elements"`
+}
+
+type overpassElement struct {
+	Type   string            `json:"type"`
+	Lat    float64           `json:"lat"`
+	Lon    float64           `json:"lon"`
+	Center *overpassCenter   `json:"center"`
+	Tags   map[string]string `json:"tags"`
+}
+
+type overpassCenter struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// elementToPlace maps an Overpass element's OSM tags onto a Place. Ways
+// and relations report their location via "center" rather than lat/lon.
+func elementToPlace(element overpassElement) Place {
+	lat, lon := element.Lat, element.Lon
+	if element.Center != nil {
+		lat, lon = element.Center.Lat, element.Center.Lon
+	}
+
+	return Place{
+		Name:        element.Tags["name"],
+		Address:     buildAddress(element.Tags),
+		Coordinates: Coordinates{Lat: lat, Lon: lon},
+		Phone:       firstNonEmpty(element.Tags["phone"], element.Tags["contact:phone"]),
+		Website:     firstNonEmpty(element.Tags["website"], element.Tags["contact:website"]),
+		Hours:       element.Tags["opening_hours"],
+	}
+}
+
+// buildAddress assembles a street address from OSM's addr:* tags.
+func buildAddress(tags map[string]string) string {
+	parts := []string{}
+	if street := tags["addr:housenumber"] + " " + tags["addr:street"]; strings.TrimSpace(street) != "" {
+		parts = append(parts, strings.TrimSpace(street))
+	}
+	if city := tags["addr:city"]; city != "" {
+		parts = append(parts, city)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
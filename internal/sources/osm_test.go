@@ -0,0 +1,54 @@
+package sources
+
+import "testing"
+
+func TestElementToPlace(t *testing.T) {
+	element := overpassElement{
+		Type: "node",
+		Lat:  40.7128,
+		Lon:  -74.0060,
+		Tags: map[string]string{
+			"name":             "Joe's Coffee",
+			"addr:housenumber": "123",
+			"addr:street":      "Main St",
+			"addr:city":        "New York",
+			"phone":            "+1-555-0100",
+			"website":          "https://joescoffee.example.com",
+			"opening_hours":    "Mo-Fr 08:00-18:00",
+		},
+	}
+
+	place := elementToPlace(element)
+
+	if place.Name != "Joe's Coffee" {
+		t.Errorf("Name = %q, want %q", place.Name, "Joe's Coffee")
+	}
+	if want := "123 Main St, New York"; place.Address != want {
+		t.Errorf("Address = %q, want %q", place.Address, want)
+	}
+	if place.Coordinates != (Coordinates{Lat: 40.7128, Lon: -74.0060}) {
+		t.Errorf("Coordinates = %+v, want node's own lat/lon", place.Coordinates)
+	}
+}
+
+func TestElementToPlaceUsesCenterForWays(t *testing.T) {
+	element := overpassElement{
+		Type:   "way",
+		Center: &overpassCenter{Lat: 1.5, Lon: 2.5},
+		Tags:   map[string]string{"name": "Joe's Coffee"},
+	}
+
+	place := elementToPlace(element)
+
+	if place.Coordinates != (Coordinates{Lat: 1.5, Lon: 2.5}) {
+		t.Errorf("Coordinates = %+v, want the way's center", place.Coordinates)
+	}
+}
+
+func TestEscapeOverpassRegex(t *testing.T) {
+	got := escapeOverpassRegex(`"quoted" \ value`)
+	want := `\"quoted\" \\ value`
+	if got != want {
+		t.Errorf("escapeOverpassRegex = %q, want %q", got, want)
+	}
+}
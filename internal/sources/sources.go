@@ -0,0 +1,89 @@
+// Package sources defines mapsscrap's pluggable map-source backends. A
+// MapSource turns a SearchParams into a list of Places; the Google Maps
+// scraper and the OpenStreetMap backend both implement it and register
+// themselves in a small name-based registry so the CLI can pick one (or
+// fan out across all of them) via --source.
+package sources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Coordinates represents a geographical point with latitude and longitude.
+type Coordinates struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// Place represents a business place with its details.
+type Place struct {
+	Name        string      `json:"name"`
+	Address     string      `json:"address"`
+	Stars       float64     `json:"rating"`
+	Reviews     int         `json:"reviews"`
+	Coordinates Coordinates `json:"location"`
+	Hours       string      `json:"hours,omitempty"`
+	Phone       string      `json:"phone,omitempty"`
+	Website     string      `json:"website,omitempty"`
+}
+
+// SearchParams holds the parameters for a search against a MapSource.
+type SearchParams struct {
+	Latitude  float64
+	Longitude float64
+	Query     string
+	RadiusKm  float64
+}
+
+// MapSource searches a single grid point and returns the places found
+// there. Implementations are free to hit a headless browser, a JSON API,
+// or a cache - callers only depend on this interface.
+type MapSource interface {
+	// Name identifies the source, matching the key it was registered under.
+	Name() string
+	Search(ctx context.Context, params SearchParams) ([]Place, error)
+}
+
+// Options configures a MapSource at construction time. Not every field is
+// meaningful to every source; a source ignores the options it doesn't need.
+type Options struct {
+	RulesDir              string
+	RuleName              string
+	CacheDir              string
+	CacheTTL              time.Duration
+	NoCache               bool
+}
+
+// Factory builds a MapSource from Options.
+type Factory func(Options) (MapSource, error)
+
+var registry = map[string]Factory{}
+
+// Register adds a MapSource factory under name. Implementations call this
+// from an init function so importing the sources package is enough to
+// make them available.
+func Register(name string, factory Factory) {
+	registry[name] = factory
+}
+
+// New builds the named MapSource.
+func New(name string, opts Options) (MapSource, error) {
+	factory, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown map source %q (available: %v)", name, Names())
+	}
+	return factory(opts)
+}
+
+// Names returns the registered source names, sorted.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,25 @@
+package sources
+
+import "testing"
+
+func TestNamesIncludesBuiltinSources(t *testing.T) {
+	names := Names()
+
+	want := map[string]bool{"google": false, "osm": false}
+	for _, name := range names {
+		if _, ok := want[name]; ok {
+			want[name] = true
+		}
+	}
+	for name, found := range want {
+		if !found {
+			t.Errorf("expected %q to be registered, got %v", name, names)
+		}
+	}
+}
+
+func TestNewUnknownSource(t *testing.T) {
+	if _, err := New("bing", Options{}); err == nil {
+		t.Fatal("expected an error for an unregistered source name")
+	}
+}
@@ -2,18 +2,18 @@ package main
 
 import (
 	"context"
-	"encoding/csv"
 	"fmt"
 	"math"
 	"os"
 	"path/filepath"
-	"strings"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/go-rod/rod"
-	"github.com/go-rod/rod/lib/launcher"
-	"github.com/go-rod/rod/lib/proto"
+	"github.com/edlgg/mapsscrap/internal/checkpoint"
+	"github.com/edlgg/mapsscrap/internal/export"
+	"github.com/edlgg/mapsscrap/internal/sources"
 	"github.com/schollz/progressbar/v3"
 	"github.com/spf13/cobra"
 )
@@ -24,33 +24,17 @@ const (
 	gridStepKm  = 2.5   // Distance between grid points in kilometers
 	maxWorkers  = 4     // Maximum number of concurrent workers
 	taskDuration = 45 * time.Second // Estimated duration for each task
+	defaultMaxSequentialFailures = 20 // Default --max-sequential-failures
 )
 
-// SearchParams holds the parameters for the search operation
-type SearchParams struct {
-	Latitude   float64
-	Longitude  float64
-	Query string
-	RadiusKm   float64
-}
-
-// Place represents a business place with its details
-type Place struct {
-	Name        string      `json:"name"`
-	Address     string      `json:"address"`
-	Stars       float64     `json:"rating"`
-	Reviews     int         `json:"reviews"`
-	Coordinates Coordinates `json:"location"`
-	Hours       string      `json:"hours,omitempty"`
-	Phone       string      `json:"phone,omitempty"`
-	Website     string      `json:"website,omitempty"`
-}
-
-// Coordinates represents a geographical point with latitude and longitude
-type Coordinates struct {
-	Lat float64 `json:"lat"`
-	Lon float64 `json:"lon"`
-}
+// SearchParams, Place, and Coordinates are the shared domain types for all
+// map-source backends, defined in the sources package and aliased here so
+// the rest of main doesn't need to qualify them.
+type (
+	SearchParams = sources.SearchParams
+	Place        = sources.Place
+	Coordinates  = sources.Coordinates
+)
 
 // Global variables for command-line flags
 // Need to have these because of the way Cobra works
@@ -59,6 +43,18 @@ var (
 	longitude  float64
 	searchTerm string
 	radiusKm   float64
+	rulesDir   string
+	ruleName   string
+	cacheDir   string
+	cacheTTL   time.Duration
+	noCache    bool
+	maxSequentialFailures int
+	sourceName string
+	outputFormats []string
+	outputPath    string
+	stateDir   string
+	resume     bool
+	newRun     bool
 )
 
 // runSearchCmd runs the runSearch job
@@ -88,6 +84,18 @@ func init() {
 	runSearchCmd.Flags().Float64VarP(&longitude, "lon", "o", 0, "Longitude of search center")
 	runSearchCmd.Flags().StringVarP(&searchTerm, "query", "q", "", "Search query")
 	runSearchCmd.Flags().Float64VarP(&radiusKm, "radius", "r", 2.0, "Search radius in kilometers")
+	runSearchCmd.Flags().StringVar(&rulesDir, "rules-dir", "", "Directory of scraper rule files (YAML/JSON); defaults to the built-in Google Maps rules")
+	runSearchCmd.Flags().StringVar(&ruleName, "rule-name", "", "Rule set to use, by its \"name\" field, when --rules-dir contains more than one rule file")
+	runSearchCmd.Flags().StringVar(&cacheDir, "cache-dir", defaultCacheDir(), "Directory for cached result pages and geocoded addresses")
+	runSearchCmd.Flags().DurationVar(&cacheTTL, "cache-ttl", 24*time.Hour, "How long cached entries remain valid before being re-scraped or re-geocoded")
+	runSearchCmd.Flags().BoolVar(&noCache, "no-cache", false, "Disable the result-page and geocode caches")
+	runSearchCmd.Flags().IntVar(&maxSequentialFailures, "max-sequential-failures", defaultMaxSequentialFailures, "Abort the run after this many consecutive worker timeouts or errors")
+	runSearchCmd.Flags().StringVar(&sourceName, "source", "google", fmt.Sprintf("Map source(s) to search: %v, or \"all\" to fan out across every source", sources.Names()))
+	runSearchCmd.Flags().StringSliceVar(&outputFormats, "output-format", []string{"csv"}, fmt.Sprintf("Output format(s) to write, repeatable: %v", export.Names()))
+	runSearchCmd.Flags().StringVar(&outputPath, "output", "", "Base path for output files (extension added per format); defaults to ./prospects_<timestamp>")
+	runSearchCmd.Flags().StringVar(&stateDir, "state-dir", defaultStateDir(), "Directory for run checkpoints, enabling --resume")
+	runSearchCmd.Flags().BoolVar(&resume, "resume", false, "Resume a previous run's checkpoint (matched by query, center, radius) instead of starting over")
+	runSearchCmd.Flags().BoolVar(&newRun, "new-run", false, "Discard any existing checkpoint for this query/center/radius and start fresh")
 
 	runSearchCmd.MarkFlagRequired("lat")
 	runSearchCmd.MarkFlagRequired("lon")
@@ -107,6 +115,54 @@ func Execute() {
 	}
 }
 
+// defaultCacheDir returns ~/.cache/mapsscrap, falling back to a relative
+// directory if the user's home directory can't be determined.
+func defaultCacheDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".mapsscrap-cache"
+	}
+	return filepath.Join(home, ".cache", "mapsscrap")
+}
+
+// defaultStateDir returns ~/.local/state/mapsscrap, falling back to a
+// relative directory if the user's home directory can't be determined.
+func defaultStateDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".mapsscrap-state"
+	}
+	return filepath.Join(home, ".local", "state", "mapsscrap")
+}
+
+// loadActiveSources builds the MapSource(s) selected by --source: a single
+// named source, or every registered source when name is "all", in which
+// case their results are merged and deduped downstream by launchScrappingWorkers.
+func loadActiveSources(name string) ([]sources.MapSource, error) {
+	opts := sources.Options{
+		RulesDir: rulesDir,
+		RuleName: ruleName,
+		CacheDir: cacheDir,
+		CacheTTL: cacheTTL,
+		NoCache:  noCache,
+	}
+
+	names := []string{name}
+	if name == "all" {
+		names = sources.Names()
+	}
+
+	active := make([]sources.MapSource, 0, len(names))
+	for _, n := range names {
+		src, err := sources.New(n, opts)
+		if err != nil {
+			return nil, err
+		}
+		active = append(active, src)
+	}
+	return active, nil
+}
+
 // runSearch executes the search operation based on provided parameters
 // It generates a grid of points within the specified radius and launches workers
 // to scrape Google Maps for business information at each point.
@@ -115,6 +171,12 @@ func runSearch(params SearchParams) {
 		fmt.Println("Radius is very large, this may take a long time.")
 	}
 
+	activeSources, err := loadActiveSources(sourceName)
+	if err != nil {
+		fmt.Printf("Error setting up map source(s): %v\n", err)
+		return
+	}
+
 	// Generate grid points around the center coordinates
 	gridPoints := generateSearchGrid(
 		params.Latitude,
@@ -123,32 +185,118 @@ func runSearch(params SearchParams) {
 		gridStepKm,
 	)
 
-	// Validate grid points
-	allPlaces := launchScrappingWorkers(params, gridPoints)
+	store, err := checkpoint.NewStore(stateDir)
+	if err != nil {
+		fmt.Printf("Error setting up checkpoint store: %v\n", err)
+		return
+	}
+
+	runID := checkpoint.RunID(params.Query, Coordinates{Lat: params.Latitude, Lon: params.Longitude}, params.RadiusKm, gridStepKm)
+	if newRun {
+		if err := store.Delete(runID); err != nil {
+			fmt.Printf("Warning: failed to clear previous checkpoint: %v\n", err)
+		}
+	}
+
+	completed := make(map[int]bool)
+	var seedPlaces []Place
+	if resume {
+		cp, found, err := store.Load(runID)
+		if err != nil {
+			fmt.Printf("Warning: failed to load checkpoint, starting fresh: %v\n", err)
+		} else if found {
+			for _, idx := range cp.CompletedIndices {
+				completed[idx] = true
+			}
+			seedPlaces = cp.Places
+			fmt.Printf("Resuming run %s: %d/%d points already completed, %d places loaded.\n", runID, len(completed), len(gridPoints), len(seedPlaces))
+		}
+	}
+
+	allPlaces, err := launchScrappingWorkers(params, gridPoints, activeSources, store, runID, completed, seedPlaces)
+	if err != nil {
+		fmt.Println(err)
+	}
 	if len(allPlaces) == 0 {
 		fmt.Println("No places found for the given search parameters.")
 		return
 	}
 
-	// Save results to CSV file
-	workDir, err := os.Getwd()
+	// Write results out in each requested format
+	basePath, err := resolveOutputPath(outputPath)
 	if err != nil {
-		fmt.Printf("Error getting current working directory: %v\n", err)
+		fmt.Printf("Error resolving output path: %v\n", err)
 		return
 	}
-	now := time.Now()
-	fileName := fmt.Sprintf("prospects_%s.csv", now.Format("2006-01-02_15-04-05"))
-	savePath := filepath.Join(workDir, fileName)
-	if err := savePlacesToCSV(allPlaces, savePath); err != nil {
-		fmt.Printf("Error saving places to CSV: %v\n", err)
-		return
+
+	for _, format := range outputFormats {
+		exporter, err := export.Get(format)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			continue
+		}
+		if err := exporter.Export(allPlaces, basePath); err != nil {
+			fmt.Printf("Error exporting %s: %v\n", format, err)
+			continue
+		}
+		fmt.Printf("%d places exported to %s (%s)\n", len(allPlaces), basePath, format)
 	}
-	fmt.Printf("%d places saved to %s\n", len(allPlaces), savePath)
+}
+
+// resolveOutputPath returns outputPath if set, or a timestamped
+// ./prospects_<timestamp> base path in the current working directory.
+func resolveOutputPath(outputPath string) (string, error) {
+	if outputPath != "" {
+		return outputPath, nil
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current working directory: %w", err)
+	}
+	fileName := fmt.Sprintf("prospects_%s", time.Now().Format("2006-01-02_15-04-05"))
+	return filepath.Join(workDir, fileName), nil
+}
+
+// workerStatusKind classifies how a single grid point search concluded.
+type workerStatusKind string
+
+const (
+	statusOK      workerStatusKind = "ok"
+	statusTimeout workerStatusKind = "timeout"
+	statusError   workerStatusKind = "error"
+)
+
+// workerStatus reports the outcome of a single grid point search, used to
+// drive the circuit breaker and the progress bar's failure ratio. Index
+// identifies the grid point; a status is only ever sent for an index whose
+// worker actually ran a search, so receiving one is also how callers learn
+// an index is safe to mark completed in a checkpoint.
+type workerStatus struct {
+	Index int
+	Point Coordinates
+	Kind  workerStatusKind
 }
 
 // launchScrappingWorkers starts multiple goroutines to scrape Google Maps for business information
-// at various grid points around the specified center coordinates.
-func launchScrappingWorkers(params SearchParams, gridPoints []Coordinates) []Place {
+// at various grid points around the specified center coordinates. A shared
+// circuit breaker cancels the remaining work once --max-sequential-failures
+// consecutive timeouts or errors are observed (the count carries over from
+// one batch to the next and resets on any success), so a broken
+// selector or an IP block doesn't burn through the entire grid; the
+// cancellation is only acted on once a batch fully finishes, so it always
+// stops work before the next batch rather than partway through the current
+// one.
+//
+// Progress is checkpointed to store after every batch, keyed by runID, so
+// an aborted run (circuit breaker trip, crash, Ctrl-C) can pick up where
+// it left off via --resume instead of re-scraping completed points. A grid
+// index is only marked completed once its worker reports a status, so a
+// point whose search never ran (such as one in a batch that was never
+// dispatched after the breaker tripped) is never incorrectly skipped on
+// resume. completed and seedPlaces carry over state from a
+// prior checkpoint; pass empty/nil values for a fresh run.
+func launchScrappingWorkers(params SearchParams, gridPoints []Coordinates, activeSources []sources.MapSource, store *checkpoint.Store, runID string, completed map[int]bool, seedPlaces []Place) ([]Place, error) {
 	text := fmt.Sprintf("Searching %d locations in a radius of %.1f km around (%.6f, %.6f) for query '%s'.",
 		len(gridPoints), params.RadiusKm, params.Latitude, params.Longitude, params.Query)
 	fmt.Println(text)
@@ -156,49 +304,107 @@ func launchScrappingWorkers(params SearchParams, gridPoints []Coordinates) []Pla
 	estimatedTime := estimateJobTime(len(gridPoints), maxWorkers)
 	barText := fmt.Sprintf("Please wait... Estimated time: %s", estimatedTime)
 	bar := progressbar.Default(int64(len(gridPoints)), barText)
+	bar.Add(len(completed))
 
 	maxWorkers := maxWorkers
-	results := make(chan []Place, len(gridPoints))
-	var wg sync.WaitGroup
 
-	// Process grid points in batches
-	for i := 0; i < len(gridPoints); i += maxWorkers {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var sequentialFailures int32
+	allPlaces := append([]Place(nil), seedPlaces...)
+
+	// Process grid points in batches, skipping any already marked completed
+	// by a prior run of this same runID.
+	for i := 0; i < len(gridPoints) && ctx.Err() == nil; i += maxWorkers {
 		end := i + maxWorkers
 		if end > len(gridPoints) {
 			end = len(gridPoints)
 		}
 
-		// Launch workers for this batch
+		batch := make([]int, 0, maxWorkers)
 		for j := i; j < end; j++ {
+			if !completed[j] {
+				batch = append(batch, j)
+			}
+		}
+		if len(batch) == 0 {
+			continue
+		}
+
+		// Launch workers for this batch. statuses is scoped to the batch so
+		// that, once it's drained below, we know exactly which indices sent
+		// one - and therefore actually ran a search, as opposed to bailing
+		// out early via the outerCtx.Err() guard in searchWorker. The breaker
+		// itself only cancels ctx after the whole batch has finished.
+		results := make(chan []Place, len(batch))
+		statuses := make(chan workerStatus, len(batch))
+		var wg sync.WaitGroup
+		for _, j := range batch {
 			wg.Add(1)
-			params := SearchParams{
-				Latitude:   gridPoints[j].Lat,
-				Longitude:  gridPoints[j].Lon,
-				Query: params.Query,
-				RadiusKm:   1.0,
+			pointParams := SearchParams{
+				Latitude:  gridPoints[j].Lat,
+				Longitude: gridPoints[j].Lon,
+				Query:     params.Query,
+				RadiusKm:  1.0,
 			}
 
-			go searchWorker(params, results, &wg, bar)
+			go searchWorker(ctx, j, pointParams, activeSources, results, statuses, &wg, bar)
 		}
 
 		// Wait for batch to complete
 		wg.Wait()
+		close(results)
+		close(statuses)
+
+		for places := range results {
+			for _, place := range places {
+				if !containsPlace(allPlaces, place) {
+					allPlaces = append(allPlaces, place)
+				}
+			}
+		}
+
+		for status := range statuses {
+			failures := int32(0)
+			if status.Kind == statusOK {
+				atomic.StoreInt32(&sequentialFailures, 0)
+			} else {
+				failures = atomic.AddInt32(&sequentialFailures, 1)
+			}
+			bar.Describe(fmt.Sprintf("%s (consecutive failures: %d/%d)", barText, failures, maxSequentialFailures))
+			if int(failures) >= maxSequentialFailures {
+				cancel()
+			}
+			completed[status.Index] = true
+		}
+
+		if err := store.Save(&checkpoint.Checkpoint{RunID: runID, CompletedIndices: sortedIndices(completed), Places: allPlaces}); err != nil {
+			fmt.Printf("Warning: failed to save checkpoint: %v\n", err)
+		}
+
 		time.Sleep(2 * time.Second) // Rate limiting between batches
 	}
 
-	// Collect all results
-	allPlaces := make([]Place, 0)
-	close(results)
+	if ctx.Err() != nil {
+		return allPlaces, fmt.Errorf("aborted after %d consecutive worker failures; %d places collected before stopping (use --resume to continue this run)", maxSequentialFailures, len(allPlaces))
+	}
 
-	// Process results and remove duplicates
-	for places := range results {
-		for _, place := range places {
-			if !containsPlace(allPlaces, place) {
-				allPlaces = append(allPlaces, place)
-			}
-		}
+	if err := store.Delete(runID); err != nil {
+		fmt.Printf("Warning: failed to remove checkpoint for a completed run: %v\n", err)
+	}
+	return allPlaces, nil
+}
+
+// sortedIndices returns the keys of completed as a sorted slice, for
+// deterministic checkpoint output.
+func sortedIndices(completed map[int]bool) []int {
+	indices := make([]int, 0, len(completed))
+	for i := range completed {
+		indices = append(indices, i)
 	}
-	return allPlaces
+	sort.Ints(indices)
+	return indices
 }
 
 // estimateJobTime calculates the estimated time to complete the job.
@@ -222,26 +428,54 @@ func estimateJobTime(numTasks int, maxWorkers int) time.Duration {
     return totalTime
 }
 
-// searchWorker performs the actual scraping for a single grid point.
-// It launches a browser, navigates to Google Maps, and extracts information.
-func searchWorker(params SearchParams, results chan<- []Place, wg *sync.WaitGroup, bar *progressbar.ProgressBar) {
+// searchWorker performs the actual search for a single grid point against
+// every active map source, merging their places into one result.
+// outerCtx is shared across all workers: once the circuit breaker trips it
+// is cancelled, and any worker not yet started skips its search entirely
+// without sending a status for index, so the caller never marks that grid
+// point completed and it gets retried on resume.
+func searchWorker(outerCtx context.Context, index int, params SearchParams, activeSources []sources.MapSource, results chan<- []Place, statuses chan<- workerStatus, wg *sync.WaitGroup, bar *progressbar.ProgressBar) {
 	defer wg.Done()
 	defer bar.Add(1)
 
-	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
+	point := Coordinates{Lat: params.Latitude, Lon: params.Longitude}
+
+	if outerCtx.Err() != nil {
+		return
+	}
+
+	// Create context with timeout, cancelled early if the circuit breaker trips
+	ctx, cancel := context.WithTimeout(outerCtx, 45*time.Second)
 	defer cancel()
 
-	// Create done channel for timeout handling
-	done := make(chan bool)
+	// Buffered so the search goroutine below never blocks sending on done
+	// after this function has already returned via the ctx.Done() case -
+	// an unbuffered channel would leak that goroutine forever.
+	done := make(chan bool, 1)
 	var places []Place
 	var err error
 
-	// Run scraping in goroutine
+	// Run the search against every active source in a goroutine
 	go func() {
-		places, err = scrapeGoogleMaps(params)
-		if err != nil {
-			fmt.Printf("Error searching at point %.6f, %.6f: %v\n", params.Latitude, params.Longitude, err)
+		var combined []Place
+		var firstErr error
+		succeeded := false
+		for _, source := range activeSources {
+			sourcePlaces, sourceErr := source.Search(ctx, params)
+			if sourceErr != nil {
+				fmt.Printf("Error searching %s at point %.6f, %.6f: %v\n", source.Name(), params.Latitude, params.Longitude, sourceErr)
+				if firstErr == nil {
+					firstErr = sourceErr
+				}
+				continue
+			}
+			succeeded = true
+			combined = append(combined, sourcePlaces...)
+		}
+
+		places = combined
+		if !succeeded && firstErr != nil {
+			err = firstErr
 		}
 		done <- true
 	}()
@@ -251,9 +485,13 @@ func searchWorker(params SearchParams, results chan<- []Place, wg *sync.WaitGrou
 	case <-done:
 		if err == nil {
 			results <- places
+			statuses <- workerStatus{Index: index, Point: point, Kind: statusOK}
+		} else {
+			statuses <- workerStatus{Index: index, Point: point, Kind: statusError}
 		}
 	case <-ctx.Done():
 		fmt.Printf("Search timed out for coordinates: %.6f, %.6f\n", params.Latitude, params.Longitude)
+		statuses <- workerStatus{Index: index, Point: point, Kind: statusTimeout}
 	}
 }
 
@@ -292,159 +530,3 @@ func generateSearchGrid(centerLat, centerLng float64, radiusKm float64, stepKm f
 
 	return points
 }
-
-// scrapeGoogleMaps performs the actual scraping of Google Maps
-// It maps HTML elements to relevant fields.
-func scrapeGoogleMaps(params SearchParams) ([]Place, error) {
-	// Launch browser
-	launch := launcher.New().
-		Headless(true).
-		Devtools(false)
-
-	url, err := launch.Launch()
-	if err != nil {
-		return nil, fmt.Errorf("failed to launch browser: %w", err)
-	}
-
-	browser := rod.New().ControlURL(url).MustConnect()
-	defer browser.Close()
-
-	page := browser.MustPage()
-	defer page.Close()
-
-	// Navigate to Google Maps
-	mapURL := fmt.Sprintf("https://www.google.com/maps/search/%s/@%f,%f,15z",
-		params.Query,
-		params.Latitude,
-		params.Longitude,
-	)
-
-	if err := page.Navigate(mapURL); err != nil {
-		return nil, fmt.Errorf("failed to navigate: %w", err)
-	}
-
-	page.MustWaitStable()
-
-	listDivClass := "m6QErb.DxyBCb.kA9KIf.dS8AEf"
-	places := []Place{}
-
-	container := page.MustElement("div." + listDivClass)
-	container.MustWaitVisible()
-
-	// move mouse pointer to list which is first third of screen and scroll
-	for i := 0; i < 10; i++ { // 10
-		page.Mouse.MoveTo(proto.Point{X: 250, Y: 300})
-		page.Mouse.Scroll(0.0, 6000.0, 30)
-		// page.Mouse.Scroll(0.0, 1000.0, 5)
-		time.Sleep(500 * time.Millisecond)
-	}
-
-	placeElements := container.MustElements("div.Nv2PK")
-
-	for _, element := range placeElements {
-		place := extractPlaceDetails(element, params)
-		if place.Name != "" {
-			places = append(places, place)
-		}
-	}
-
-	return places, nil
-}
-
-// extractPlaceDetails extracts details of a place from the given element
-// It retrieves the name, address, rating, reviews, phone number, opening hours, and website
-// from the Google Maps search result element.
-func extractPlaceDetails(element *rod.Element, params SearchParams) Place {
-	place := Place{
-		Coordinates: Coordinates{
-			Lat: params.Latitude,
-			Lon: params.Longitude,
-		},
-	}
-
-	// Extract place details
-	if nameEl, err := element.Element("div.qBF1Pd.fontHeadlineSmall"); err == nil {
-		place.Name = nameEl.MustText()
-	}
-
-	if ratingEl, err := element.Element("span.MW4etd"); err == nil {
-		ratingText := ratingEl.MustText()
-		fmt.Sscanf(ratingText, "%f", &place.Stars)
-	}
-
-	if reviewsEl, err := element.Element("span.UY7F9"); err == nil {
-		reviewText := reviewsEl.MustText()
-		fmt.Sscanf(reviewText, "(%d)", &place.Reviews)
-	}
-
-	if addressEl, err := element.Element("div.W4Efsd:nth-child(1)"); err == nil {
-		line, err := addressEl.Text()
-		if err == nil {
-			lineSplit := strings.Split(line, "·")
-			address := lineSplit[len(lineSplit)-1]
-			place.Address = address
-		}
-	}
-
-	if oppeningHoursEl, err := element.Element("div.W4Efsd:nth-child(2)"); err == nil {
-		line, err := oppeningHoursEl.Text()
-		if err == nil {
-			lineSplit := strings.Split(line, "·")
-			if len(lineSplit) > 1 {
-				openingHours := lineSplit[0]
-				place.Hours = openingHours
-			}
-		}
-	}
-
-	if phoneEl, err := element.Element("div.W4Efsd span.UsdlK"); err == nil {
-		phone, err := phoneEl.Text()
-		if err == nil {
-			place.Phone = phone
-		}
-	}
-
-	if websiteEl, err := element.Element("a.lcr4fd"); err == nil {
-		if href, err := websiteEl.Attribute("href"); err == nil {
-			place.Website = *href
-		}
-	}
-
-	return place
-}
-
-// savePlacesToCSV saves the list of places to a CSV file.
-func savePlacesToCSV(places []Place, filename string) error {
-	file, err := os.Create(filename)
-	if err != nil {
-		return fmt.Errorf("failed to create CSV file: %w", err)
-	}
-	defer file.Close()
-
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
-
-	// Write header
-	header := []string{"Name", "Address", "Stars", "Reviews", "Phone", "Hours", "Website"}
-	if err := writer.Write(header); err != nil {
-		return fmt.Errorf("failed to write header to CSV: %w", err)
-	}
-
-	// Write place data
-	for _, place := range places {
-		record := []string{
-			place.Name,
-			place.Address,
-			fmt.Sprintf("%.1f", place.Stars),
-			fmt.Sprintf("%d", place.Reviews),
-			place.Phone,
-			place.Hours,
-			place.Website,
-		}
-		if err := writer.Write(record); err != nil {
-			return fmt.Errorf("failed to write record to CSV: %w", err)
-		}
-	}
-
-	return nil
-}
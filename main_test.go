@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/edlgg/mapsscrap/internal/checkpoint"
+	"github.com/edlgg/mapsscrap/internal/sources"
+	"github.com/schollz/progressbar/v3"
+)
+
+// fakeSource is a minimal sources.MapSource used to simulate a run that
+// fails partway through, without touching a real browser or HTTP API. name
+// distinguishes places produced by different fakeSources in the same
+// search, e.g. to exercise --source=all's cross-source merge.
+type fakeSource struct {
+	name     string
+	failLats map[float64]bool
+}
+
+func (f *fakeSource) Name() string {
+	if f.name != "" {
+		return f.name
+	}
+	return "fake"
+}
+
+func (f *fakeSource) Search(ctx context.Context, params sources.SearchParams) ([]sources.Place, error) {
+	if f.failLats[params.Latitude] {
+		return nil, fmt.Errorf("simulated failure at lat %.0f", params.Latitude)
+	}
+	return []sources.Place{{
+		Name:    fmt.Sprintf("%s Place %.0f", f.Name(), params.Latitude),
+		Address: fmt.Sprintf("Addr %.0f", params.Latitude),
+	}}, nil
+}
+
+// gridOfSize returns n grid points with distinct latitudes, so fakeSource
+// can target specific indices by latitude.
+func gridOfSize(n int) []Coordinates {
+	points := make([]Coordinates, n)
+	for i := 0; i < n; i++ {
+		points[i] = Coordinates{Lat: float64(i), Lon: 0}
+	}
+	return points
+}
+
+// TestLaunchScrappingWorkersResumesAfterInterruption simulates a run that
+// trips the circuit breaker partway through a 10-point grid (batches of
+// maxWorkers=4), then resumes from the saved checkpoint and confirms it
+// only re-scrapes the points that were never attempted.
+func TestLaunchScrappingWorkersResumesAfterInterruption(t *testing.T) {
+	oldMaxSequentialFailures := maxSequentialFailures
+	maxSequentialFailures = 2
+	t.Cleanup(func() { maxSequentialFailures = oldMaxSequentialFailures })
+
+	store, err := checkpoint.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+
+	params := SearchParams{Query: "coffee", RadiusKm: 2}
+	gridPoints := gridOfSize(10)
+	runID := checkpoint.RunID(params.Query, Coordinates{}, params.RadiusKm, gridStepKm)
+
+	// First run: the second batch (indices 4-7) fails outright, tripping
+	// the circuit breaker before the third batch (indices 8-9) starts.
+	failing := &fakeSource{failLats: map[float64]bool{4: true, 5: true, 6: true, 7: true}}
+	places, err := launchScrappingWorkers(params, gridPoints, []sources.MapSource{failing}, store, runID, map[int]bool{}, nil)
+	if err == nil {
+		t.Fatal("expected the circuit breaker to abort the run")
+	}
+	if len(places) != 4 {
+		t.Fatalf("got %d places after the interrupted run, want 4", len(places))
+	}
+
+	cp, found, err := store.Load(runID)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !found {
+		t.Fatal("expected a checkpoint to survive the aborted run")
+	}
+	if len(cp.CompletedIndices) != 8 {
+		t.Errorf("got %d completed indices, want 8 (points 8-9 were never attempted)", len(cp.CompletedIndices))
+	}
+
+	// Resume: everything succeeds now, so only the untouched points (8-9)
+	// should be scraped, and the checkpoint should be cleaned up.
+	completed := make(map[int]bool)
+	for _, idx := range cp.CompletedIndices {
+		completed[idx] = true
+	}
+
+	succeeding := &fakeSource{}
+	places, err = launchScrappingWorkers(params, gridPoints, []sources.MapSource{succeeding}, store, runID, completed, cp.Places)
+	if err != nil {
+		t.Fatalf("expected the resumed run to finish cleanly, got: %v", err)
+	}
+	if len(places) != 6 {
+		t.Fatalf("got %d places after resuming, want 6 (4 carried over + 2 newly scraped)", len(places))
+	}
+
+	if _, found, err := store.Load(runID); err != nil {
+		t.Fatalf("Load: %v", err)
+	} else if found {
+		t.Error("expected the checkpoint to be removed after the run completed successfully")
+	}
+}
+
+// TestSearchWorkerSkipsWhenAlreadyCancelled guards the invariant that
+// launchScrappingWorkers relies on to build its checkpoint: a worker that
+// bails out early because the shared context is already cancelled must not
+// report any result or status for its index, since either one would cause
+// that grid point to be marked completed and skipped on --resume even
+// though no search was ever run for it.
+func TestSearchWorkerSkipsWhenAlreadyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := make(chan []Place, 1)
+	statuses := make(chan workerStatus, 1)
+	bar := progressbar.Default(1)
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	searchWorker(ctx, 0, SearchParams{Latitude: 1, Longitude: 2}, []sources.MapSource{&fakeSource{}}, results, statuses, &wg, bar)
+	wg.Wait()
+	close(results)
+	close(statuses)
+
+	if len(results) != 0 {
+		t.Error("expected no places to be produced for an already-cancelled context")
+	}
+	if len(statuses) != 0 {
+		t.Error("expected no status to be reported for an already-cancelled context, so its grid index is never marked completed")
+	}
+}
+
+// TestSearchWorkerMergesPlacesAcrossSources exercises --source=all: a
+// single grid point is searched against every active source, and the
+// worker must merge their results into one combined slice of places rather
+// than keeping only the last source searched.
+func TestSearchWorkerMergesPlacesAcrossSources(t *testing.T) {
+	first := &fakeSource{name: "google"}
+	second := &fakeSource{name: "osm"}
+
+	results := make(chan []Place, 1)
+	statuses := make(chan workerStatus, 1)
+	bar := progressbar.Default(1)
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	searchWorker(context.Background(), 0, SearchParams{Latitude: 1, Longitude: 2}, []sources.MapSource{first, second}, results, statuses, &wg, bar)
+	wg.Wait()
+	close(results)
+	close(statuses)
+
+	places := <-results
+	if len(places) != 2 {
+		t.Fatalf("got %d places, want 2 (one from each source)", len(places))
+	}
+	if places[0].Name != "google Place 1" || places[1].Name != "osm Place 1" {
+		t.Errorf("got places %v, want one named %q and one named %q", places, "google Place 1", "osm Place 1")
+	}
+
+	if status := <-statuses; status.Kind != statusOK {
+		t.Errorf("got status %v, want statusOK", status.Kind)
+	}
+}
+
+// TestSearchWorkerSuppressesErrorOnPartialSuccess guards the merge loop's
+// error handling: when one of several sources fails for a grid point but
+// another succeeds, the worker must report statusOK (and the successful
+// source's places) rather than discarding the point as an error - only a
+// point where every source fails should be reported as an error.
+func TestSearchWorkerSuppressesErrorOnPartialSuccess(t *testing.T) {
+	failing := &fakeSource{name: "bad", failLats: map[float64]bool{1: true}}
+	succeeding := &fakeSource{name: "good"}
+
+	results := make(chan []Place, 1)
+	statuses := make(chan workerStatus, 1)
+	bar := progressbar.Default(1)
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	searchWorker(context.Background(), 0, SearchParams{Latitude: 1, Longitude: 2}, []sources.MapSource{failing, succeeding}, results, statuses, &wg, bar)
+	wg.Wait()
+	close(results)
+	close(statuses)
+
+	places := <-results
+	if len(places) != 1 || places[0].Name != "good Place 1" {
+		t.Fatalf("got %v, want only the succeeding source's place", places)
+	}
+	if status := <-statuses; status.Kind != statusOK {
+		t.Errorf("got status %v, want statusOK since one of two sources succeeded", status.Kind)
+	}
+
+	// When every source fails for the point, the error must not be
+	// swallowed: the worker should report statusError.
+	bothFailing := &fakeSource{name: "bad2", failLats: map[float64]bool{1: true}}
+	results = make(chan []Place, 1)
+	statuses = make(chan workerStatus, 1)
+	wg.Add(1)
+	searchWorker(context.Background(), 0, SearchParams{Latitude: 1, Longitude: 2}, []sources.MapSource{failing, bothFailing}, results, statuses, &wg, bar)
+	wg.Wait()
+	close(results)
+	close(statuses)
+
+	if len(results) != 0 {
+		t.Error("expected no places when every source fails")
+	}
+	if status := <-statuses; status.Kind != statusError {
+		t.Errorf("got status %v, want statusError when every source fails for a point", status.Kind)
+	}
+}